@@ -0,0 +1,118 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/memorydb"
+)
+
+// ElastiCacheCloudProvider discovers AWS ElastiCache for Redis replication groups in
+// the account/region selected by the default AWS SDK credential chain.
+type ElastiCacheCloudProvider struct{}
+
+func (p *ElastiCacheCloudProvider) Name() string { return "aws-elasticache" }
+
+func (p *ElastiCacheCloudProvider) Discover(ctx context.Context) ([]Target, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	client := elasticache.New(sess)
+
+	var targets []Target
+	err = client.DescribeReplicationGroupsPagesWithContext(ctx, &elasticache.DescribeReplicationGroupsInput{},
+		func(page *elasticache.DescribeReplicationGroupsOutput, lastPage bool) bool {
+			for _, group := range page.ReplicationGroups {
+				targets = append(targets, elastiCacheReplicationGroupTargets(group)...)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+// elastiCacheReplicationGroupTargets builds the scrape targets for one replication
+// group. Cluster-mode-enabled groups carry a single ConfigurationEndpoint covering
+// every shard; the far more common cluster-mode-disabled topology has no
+// ConfigurationEndpoint at all and instead exposes one NodeGroup whose
+// PrimaryEndpoint/ReadEndpoint per member must be read individually.
+func elastiCacheReplicationGroupTargets(group *elasticache.ReplicationGroup) []Target {
+	alias := aws.StringValue(group.ReplicationGroupId)
+	tlsEnabled := group.TransitEncryptionEnabled != nil && *group.TransitEncryptionEnabled
+	labels := map[string]string{"endpoint_type": "elasticache"}
+
+	if group.ConfigurationEndpoint != nil {
+		return []Target{{
+			Addr:   fmt.Sprintf("%s:%d", aws.StringValue(group.ConfigurationEndpoint.Address), aws.Int64Value(group.ConfigurationEndpoint.Port)),
+			Alias:  alias,
+			TLS:    tlsEnabled,
+			Labels: labels,
+		}}
+	}
+
+	var targets []Target
+	for _, nodeGroup := range group.NodeGroups {
+		if nodeGroup.PrimaryEndpoint != nil {
+			targets = append(targets, Target{
+				Addr:   fmt.Sprintf("%s:%d", aws.StringValue(nodeGroup.PrimaryEndpoint.Address), aws.Int64Value(nodeGroup.PrimaryEndpoint.Port)),
+				Alias:  alias,
+				TLS:    tlsEnabled,
+				Labels: labels,
+			})
+		}
+		for i, member := range nodeGroup.NodeGroupMembers {
+			if aws.StringValue(member.CurrentRole) != "replica" || member.ReadEndpoint == nil {
+				continue
+			}
+			targets = append(targets, Target{
+				Addr:   fmt.Sprintf("%s:%d", aws.StringValue(member.ReadEndpoint.Address), aws.Int64Value(member.ReadEndpoint.Port)),
+				Alias:  fmt.Sprintf("%s-replica-%d", alias, i),
+				TLS:    tlsEnabled,
+				Labels: labels,
+			})
+		}
+	}
+	return targets
+}
+
+// MemoryDBCloudProvider discovers AWS MemoryDB for Redis clusters in the
+// account/region selected by the default AWS SDK credential chain.
+type MemoryDBCloudProvider struct{}
+
+func (p *MemoryDBCloudProvider) Name() string { return "aws-memorydb" }
+
+func (p *MemoryDBCloudProvider) Discover(ctx context.Context) ([]Target, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	client := memorydb.New(sess)
+
+	var targets []Target
+	err = client.DescribeClustersPagesWithContext(ctx, &memorydb.DescribeClustersInput{},
+		func(page *memorydb.DescribeClustersOutput, lastPage bool) bool {
+			for _, cluster := range page.Clusters {
+				if cluster.ClusterEndpoint == nil {
+					continue
+				}
+				tlsEnabled := cluster.TLSEnabled != nil && *cluster.TLSEnabled
+				targets = append(targets, Target{
+					Addr:   fmt.Sprintf("%s:%d", aws.StringValue(cluster.ClusterEndpoint.Address), aws.Int64Value(cluster.ClusterEndpoint.Port)),
+					Alias:  aws.StringValue(cluster.Name),
+					TLS:    tlsEnabled,
+					Labels: map[string]string{"endpoint_type": "memorydb"},
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
@@ -0,0 +1,65 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+)
+
+func TestElastiCacheReplicationGroupTargetsClusterModeDisabled(t *testing.T) {
+	group := &elasticache.ReplicationGroup{
+		ReplicationGroupId:       aws.String("my-repl-group"),
+		TransitEncryptionEnabled: aws.Bool(true),
+		NodeGroups: []*elasticache.NodeGroup{
+			{
+				PrimaryEndpoint: &elasticache.Endpoint{
+					Address: aws.String("primary.example.com"),
+					Port:    aws.Int64(6379),
+				},
+				NodeGroupMembers: []*elasticache.NodeGroupMember{
+					{CurrentRole: aws.String("primary")},
+					{
+						CurrentRole: aws.String("replica"),
+						ReadEndpoint: &elasticache.Endpoint{
+							Address: aws.String("replica-0.example.com"),
+							Port:    aws.Int64(6379),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	targets := elastiCacheReplicationGroupTargets(group)
+
+	if len(targets) != 2 {
+		t.Fatalf("got %d targets, want 2 (primary + 1 replica): %+v", len(targets), targets)
+	}
+	if targets[0].Addr != "primary.example.com:6379" || targets[0].Alias != "my-repl-group" {
+		t.Errorf("primary target = %+v", targets[0])
+	}
+	if !targets[0].TLS {
+		t.Errorf("expected TLS to be true for the primary target")
+	}
+	if targets[1].Addr != "replica-0.example.com:6379" || targets[1].Alias != "my-repl-group-replica-1" {
+		t.Errorf("replica target = %+v", targets[1])
+	}
+}
+
+func TestElastiCacheReplicationGroupTargetsClusterModeEnabled(t *testing.T) {
+	group := &elasticache.ReplicationGroup{
+		ReplicationGroupId: aws.String("my-repl-group"),
+		ConfigurationEndpoint: &elasticache.Endpoint{
+			Address: aws.String("config.example.com"),
+			Port:    aws.Int64(6379),
+		},
+		NodeGroups: []*elasticache.NodeGroup{{}, {}},
+	}
+
+	targets := elastiCacheReplicationGroupTargets(group)
+
+	if len(targets) != 1 || targets[0].Addr != "config.example.com:6379" {
+		t.Fatalf("got %+v, want a single target at the configuration endpoint", targets)
+	}
+}
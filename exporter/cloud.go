@@ -0,0 +1,121 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Target describes a single Redis instance discovered by a CloudProvider or any of
+// the other discovery sources (file, args, CF, Sentinel, Cluster, Kubernetes).
+type Target struct {
+	Addr     string
+	Username string
+	Password string
+	Alias    string
+	DB       int
+	TLS      bool
+	Labels   map[string]string
+}
+
+// CloudProvider discovers Redis instances managed by a cloud platform (Azure Cache
+// for Redis, AWS ElastiCache/MemoryDB, GCP Memorystore, ...). Implementations are
+// expected to do a fresh lookup on every call so they can be polled on an interval.
+type CloudProvider interface {
+	// Name identifies the provider in logs, e.g. "azure", "aws-elasticache",
+	// "gcp-memorystore". --cloud-providers entries are matched by ParseCloudProviders,
+	// not by Name.
+	Name() string
+	// Discover returns every Redis instance currently visible to this provider.
+	Discover(ctx context.Context) ([]Target, error)
+}
+
+// AzureCloudProvider discovers Azure Cache for Redis instances. It implements
+// CloudProvider on top of the same Azure SDK calls GetAzureRedisServices has always
+// used.
+type AzureCloudProvider struct{}
+
+func (p *AzureCloudProvider) Name() string { return "azure" }
+
+func (p *AzureCloudProvider) Discover(ctx context.Context) ([]Target, error) {
+	addrs, usernames, passwords, aliases, authModes, err := GetAzureRedisServices()
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]Target, 0, len(addrs))
+	for i, addr := range addrs {
+		// GetAzureRedisServices addrs are redis://host:port / rediss://host:port; strip
+		// the scheme so every CloudProvider emits the same bare host:port shape.
+		host, _, _, _, tlsEnabled, ok, err := parseRedisURI(addr)
+		if err != nil || !ok {
+			host = addr
+			tlsEnabled = strings.HasPrefix(addr, "rediss://")
+		}
+		targets = append(targets, Target{
+			Addr:     host,
+			Username: usernames[i],
+			Password: passwords[i],
+			Alias:    aliases[i],
+			TLS:      tlsEnabled,
+			Labels:   map[string]string{"auth_mode": string(authModes[i])},
+		})
+	}
+	return targets, nil
+}
+
+// ParseCloudProviders builds the list of CloudProvider implementations named in a
+// comma-separated --cloud-providers flag value, e.g. "azure,aws,gcp".
+func ParseCloudProviders(names string) ([]CloudProvider, error) {
+	var providers []CloudProvider
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		switch name {
+		case "azure":
+			providers = append(providers, &AzureCloudProvider{})
+		case "aws":
+			providers = append(providers, &ElastiCacheCloudProvider{}, &MemoryDBCloudProvider{})
+		case "gcp":
+			providers = append(providers, &MemorystoreCloudProvider{})
+		default:
+			return nil, fmt.Errorf("unknown cloud provider %q", name)
+		}
+	}
+	return providers, nil
+}
+
+// RunCloudDiscovery polls every provider on the given interval and calls onUpdate
+// with the combined target list after each round. It blocks until ctx is cancelled,
+// so callers should run it in its own goroutine.
+func RunCloudDiscovery(ctx context.Context, providers []CloudProvider, interval time.Duration, onUpdate func([]Target)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	discover := func() {
+		var all []Target
+		for _, provider := range providers {
+			targets, err := provider.Discover(ctx)
+			if err != nil {
+				log.Warnf("Cloud provider %q discovery failed: %s", provider.Name(), err)
+				continue
+			}
+			all = append(all, targets...)
+		}
+		onUpdate(all)
+	}
+
+	discover()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			discover()
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	redispb "cloud.google.com/go/redis/apiv1"
+	"google.golang.org/api/iterator"
+	redispb2 "google.golang.org/genproto/googleapis/cloud/redis/v1"
+)
+
+// MemorystoreCloudProvider discovers GCP Memorystore for Redis instances across every
+// location in the project named by the GOOGLE_CLOUD_PROJECT environment variable.
+type MemorystoreCloudProvider struct{}
+
+func (p *MemorystoreCloudProvider) Name() string { return "gcp-memorystore" }
+
+func (p *MemorystoreCloudProvider) Discover(ctx context.Context) ([]Target, error) {
+	project := os.Getenv("GOOGLE_CLOUD_PROJECT")
+	if project == "" {
+		return nil, fmt.Errorf("GOOGLE_CLOUD_PROJECT must be set to discover Memorystore instances")
+	}
+
+	client, err := redispb.NewCloudRedisClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	var targets []Target
+	it := client.ListInstances(ctx, &redispb2.ListInstancesRequest{
+		Parent: fmt.Sprintf("projects/%s/locations/-", project),
+	})
+	for {
+		instance, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if instance.Host == "" {
+			continue
+		}
+		targets = append(targets, Target{
+			Addr:   fmt.Sprintf("%s:%d", instance.Host, instance.Port),
+			Alias:  memorystoreAlias(instance.Name),
+			TLS:    instance.TransitEncryptionMode == redispb2.Instance_SERVER_AUTHENTICATION,
+			Labels: instance.Labels,
+		})
+	}
+	return targets, nil
+}
+
+// memorystoreAlias shortens a full instance resource name, e.g.
+// "projects/p/locations/us-central1/instances/my-cache", down to its last
+// path segment.
+func memorystoreAlias(name string) string {
+	if idx := strings.LastIndex(name, "/"); idx != -1 {
+		return name[idx+1:]
+	}
+	return name
+}
@@ -0,0 +1,16 @@
+package exporter
+
+import "testing"
+
+func TestMemorystoreAlias(t *testing.T) {
+	cases := map[string]string{
+		"projects/p/locations/us-central1/instances/my-cache": "my-cache",
+		"my-cache": "my-cache",
+		"":         "",
+	}
+	for name, want := range cases {
+		if got := memorystoreAlias(name); got != want {
+			t.Errorf("memorystoreAlias(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/homedir"
+)
+
+// watchRestartBackoff is how long to wait before re-establishing a watch after the
+// API server closes it (normal after ~30-60 min) or a Watch() call fails outright.
+const watchRestartBackoff = 5 * time.Second
+
+// GetKubernetesRedisTargets lists Pods matching selector in namespace and resolves
+// them into host:port addresses, pulling a shared password out of passwordSecretRef
+// (a "name/key" reference) if one is given. onUpdate is called once with the initial
+// target list and again every time a matching Pod is added, updated or removed, so
+// callers tracking a StatefulSet see members come and go without a restart. The
+// returned stop function ends the watch.
+func GetKubernetesRedisTargets(selector, namespace, passwordSecretRef string, onUpdate func(addrs, passwords, aliases []string)) (stop func(), err error) {
+	clientset, err := newKubernetesClient()
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := resolveKubernetesPasswordSecret(clientset, namespace, passwordSecretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	listOpts := metav1.ListOptions{LabelSelector: selector}
+	emit := func() {
+		pods, err := clientset.CoreV1().Pods(namespace).List(context.Background(), listOpts)
+		if err != nil {
+			log.Warnf("Unable to list redis pods: %s", err)
+			return
+		}
+		var addrs, passwords, aliases []string
+		for _, pod := range pods.Items {
+			if pod.Status.PodIP == "" {
+				continue
+			}
+			addrs = append(addrs, pod.Status.PodIP+":6379")
+			passwords = append(passwords, password)
+			aliases = append(aliases, pod.Name)
+		}
+		onUpdate(addrs, passwords, aliases)
+	}
+	emit()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		for ctx.Err() == nil {
+			watcher, err := clientset.CoreV1().Pods(namespace).Watch(ctx, listOpts)
+			if err != nil {
+				log.Warnf("Unable to watch redis pods, retrying: %s", err)
+				time.Sleep(watchRestartBackoff)
+				continue
+			}
+			watchPods(ctx, watcher, emit)
+			watcher.Stop()
+			if ctx.Err() == nil {
+				log.Warnf("Redis pod watch closed, re-establishing")
+				time.Sleep(watchRestartBackoff)
+			}
+		}
+	}()
+
+	return cancel, nil
+}
+
+// watchPods drains watcher.ResultChan(), calling emit on every Added/Modified/Deleted
+// event, until ctx is cancelled or the API server closes the channel (as it routinely
+// does every 30-60 min).
+func watchPods(ctx context.Context, watcher watch.Interface, emit func()) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return
+			}
+			switch event.Type {
+			case watch.Added, watch.Modified, watch.Deleted:
+				emit()
+			}
+		}
+	}
+}
+
+// newKubernetesClient builds a clientset from the in-cluster config, falling back to
+// KUBECONFIG (or ~/.kube/config) when not running inside a cluster.
+func newKubernetesClient() (*kubernetes.Clientset, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		kubeconfig := os.Getenv("KUBECONFIG")
+		if kubeconfig == "" {
+			kubeconfig = filepath.Join(homedir.HomeDir(), ".kube", "config")
+		}
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to build kubernetes client config: %s", err)
+		}
+	}
+	return kubernetes.NewForConfig(config)
+}
+
+// resolveKubernetesPasswordSecret reads the Redis password out of a "name/key"
+// Secret reference. An empty secretRef means no password is configured.
+func resolveKubernetesPasswordSecret(clientset kubernetes.Interface, namespace, secretRef string) (string, error) {
+	if secretRef == "" {
+		return "", nil
+	}
+	parts := strings.SplitN(secretRef, "/", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("passwordSecretRef must be in \"name/key\" form, got %q", secretRef)
+	}
+	secret, err := clientset.CoreV1().Secrets(namespace).Get(context.Background(), parts[0], metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("unable to read password secret %q: %s", parts[0], err)
+	}
+	value, ok := secret.Data[parts[1]]
+	if !ok {
+		return "", fmt.Errorf("secret %q has no key %q", parts[0], parts[1])
+	}
+	return string(value), nil
+}
@@ -0,0 +1,63 @@
+package exporter
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResolveKubernetesPasswordSecretEmptyRef(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+
+	password, err := resolveKubernetesPasswordSecret(clientset, "default", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if password != "" {
+		t.Errorf("password = %q, want empty string for an unset secretRef", password)
+	}
+}
+
+func TestResolveKubernetesPasswordSecretFound(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis-auth", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("s3cret")},
+	})
+
+	password, err := resolveKubernetesPasswordSecret(clientset, "default", "redis-auth/password")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if password != "s3cret" {
+		t.Errorf("password = %q, want %q", password, "s3cret")
+	}
+}
+
+func TestResolveKubernetesPasswordSecretMissingKey(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis-auth", Namespace: "default"},
+		Data:       map[string][]byte{"other": []byte("s3cret")},
+	})
+
+	if _, err := resolveKubernetesPasswordSecret(clientset, "default", "redis-auth/password"); err == nil {
+		t.Fatalf("expected an error for a missing secret key")
+	}
+}
+
+func TestResolveKubernetesPasswordSecretMalformedRef(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+
+	if _, err := resolveKubernetesPasswordSecret(clientset, "default", "redis-auth"); err == nil {
+		t.Fatalf("expected an error for a secretRef without a \"name/key\" separator")
+	}
+}
+
+func TestResolveKubernetesPasswordSecretNotFound(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+
+	if _, err := resolveKubernetesPasswordSecret(clientset, "default", "missing/password"); err == nil {
+		t.Fatalf("expected an error for a secret that doesn't exist")
+	}
+}
@@ -0,0 +1,176 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	log "github.com/sirupsen/logrus"
+)
+
+// ReloadSource re-runs one configured discovery source (the CSV file, args, CF,
+// Azure, or one of the cloud/Kubernetes providers) and returns its current targets.
+type ReloadSource func() ([]Target, error)
+
+// TargetRegistry holds the exporter's current scrape targets and lets callers
+// replace them atomically, so a reload never leaves an in-flight scrape looking at a
+// half-updated target list.
+type TargetRegistry struct {
+	mu      sync.RWMutex
+	targets []Target
+}
+
+func NewTargetRegistry() *TargetRegistry {
+	return &TargetRegistry{}
+}
+
+// Targets returns a snapshot of the current targets.
+func (r *TargetRegistry) Targets() []Target {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]Target, len(r.targets))
+	copy(out, r.targets)
+	return out
+}
+
+// Update atomically replaces the registry's targets with newTargets, logging any
+// additions and removals relative to the previous set.
+func (r *TargetRegistry) Update(newTargets []Target) {
+	r.mu.Lock()
+	old := r.targets
+	r.targets = newTargets
+	r.mu.Unlock()
+
+	added, removed := diffTargets(old, newTargets)
+	for _, t := range added {
+		log.Infof("Redis target added: %s (%s)", t.Addr, t.Alias)
+	}
+	for _, t := range removed {
+		log.Infof("Redis target removed: %s (%s)", t.Addr, t.Alias)
+	}
+}
+
+// Reload re-runs every source and, if all succeed, atomically swaps in their combined
+// result. It returns the error from the first source that fails and leaves the
+// registry untouched.
+func (r *TargetRegistry) Reload(sources []ReloadSource) error {
+	var all []Target
+	for _, source := range sources {
+		targets, err := source()
+		if err != nil {
+			return err
+		}
+		all = append(all, targets...)
+	}
+	r.Update(all)
+	return nil
+}
+
+func diffTargets(old, new []Target) (added, removed []Target) {
+	oldSet := make(map[string]Target, len(old))
+	for _, t := range old {
+		oldSet[t.Addr] = t
+	}
+	newSet := make(map[string]Target, len(new))
+	for _, t := range new {
+		newSet[t.Addr] = t
+	}
+	for addr, t := range newSet {
+		if _, ok := oldSet[addr]; !ok {
+			added = append(added, t)
+		}
+	}
+	for addr, t := range oldSet {
+		if _, ok := newSet[addr]; !ok {
+			removed = append(removed, t)
+		}
+	}
+	return added, removed
+}
+
+// WatchFile calls reload whenever fileName changes on disk, e.g. as edited in place
+// or replaced by a ConfigMap remount. It returns once the watch is set up; the watch
+// itself runs in its own goroutine until ctx is cancelled.
+func WatchFile(ctx context.Context, fileName string, reload func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(fileName)); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// A ConfigMap remount swaps the "..data" symlink the file resolves through
+				// rather than touching fileName itself.
+				if filepath.Clean(event.Name) != filepath.Clean(fileName) && filepath.Base(event.Name) != "..data" {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+					log.Infof("Redis target file %s changed, reloading", fileName)
+					reload()
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Warnf("Target file watcher error: %s", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// HandleSIGHUP calls reload every time the process receives SIGHUP, the standard
+// Prometheus-ecosystem signal for "re-read your configuration".
+func HandleSIGHUP(ctx context.Context, reload func()) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(sigCh)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigCh:
+				log.Infoln("Received SIGHUP, reloading redis targets")
+				reload()
+			}
+		}
+	}()
+}
+
+// ReloadHandler returns the handler for POST /-/reload: it re-runs reload and
+// reports success as plain text, the same endpoint Prometheus and its exporters
+// expose for this purpose.
+func ReloadHandler(reload func() error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if err := reload(); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "reload failed: %s\n", err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "reloaded")
+	}
+}
@@ -0,0 +1,43 @@
+package exporter
+
+import "testing"
+
+func TestDiffTargetsAddedAndRemoved(t *testing.T) {
+	old := []Target{
+		{Addr: "host-a:6379", Alias: "a"},
+		{Addr: "host-b:6379", Alias: "b"},
+	}
+	new := []Target{
+		{Addr: "host-b:6379", Alias: "b"},
+		{Addr: "host-c:6379", Alias: "c"},
+	}
+
+	added, removed := diffTargets(old, new)
+
+	if len(added) != 1 || added[0].Addr != "host-c:6379" {
+		t.Errorf("added = %+v, want a single host-c:6379 target", added)
+	}
+	if len(removed) != 1 || removed[0].Addr != "host-a:6379" {
+		t.Errorf("removed = %+v, want a single host-a:6379 target", removed)
+	}
+}
+
+func TestDiffTargetsNoChange(t *testing.T) {
+	targets := []Target{{Addr: "host-a:6379", Alias: "a"}}
+
+	added, removed := diffTargets(targets, targets)
+
+	if len(added) != 0 || len(removed) != 0 {
+		t.Errorf("expected no diff, got added=%+v removed=%+v", added, removed)
+	}
+}
+
+func TestDiffTargetsEmptyOld(t *testing.T) {
+	new := []Target{{Addr: "host-a:6379", Alias: "a"}}
+
+	added, removed := diffTargets(nil, new)
+
+	if len(added) != 1 || len(removed) != 0 {
+		t.Errorf("expected everything in new to be added, got added=%+v removed=%+v", added, removed)
+	}
+}